@@ -0,0 +1,71 @@
+package discrete
+
+// ConnectedComponents reports the weakly connected components of graph: even for a directed
+// graph, an edge in either direction is enough to place its two endpoints in the same
+// component. It builds the components with a single pass over EdgeList() using DisjointSet,
+// then buckets NodeList() by root, the same approach Kruskal uses for cycle detection.
+func ConnectedComponents(graph Graph) [][]int {
+	ds := NewDisjointSet()
+	for _, node := range graph.NodeList() {
+		ds.MakeSet(node)
+	}
+
+	for _, edge := range graph.EdgeList() {
+		if s1, s2 := ds.Find(edge[0]), ds.Find(edge[1]); s1 != s2 {
+			ds.Union(s1, s2)
+		}
+	}
+
+	buckets := make(map[int][]int)
+	for _, node := range graph.NodeList() {
+		root := ds.Find(node)
+		buckets[root] = append(buckets[root], node)
+	}
+
+	components := make([][]int, 0, len(buckets))
+	for _, nodes := range buckets {
+		components = append(components, nodes)
+	}
+
+	return components
+}
+
+// IsCyclicUndirected reports whether graph, treated as undirected, contains a cycle. It reuses
+// the same union-find as ConnectedComponents: if the two endpoints of an edge are already in
+// the same set before the union, that edge closes a cycle. Each unordered pair of endpoints is
+// only considered once, so the reciprocal edges an undirected Graph reports for a single
+// connection don't look like a cycle on their own.
+func IsCyclicUndirected(graph Graph) bool {
+	ds := NewDisjointSet()
+	for _, node := range graph.NodeList() {
+		ds.MakeSet(node)
+	}
+
+	type pair struct{ u, v int }
+	seen := make(map[pair]bool)
+
+	for _, edge := range graph.EdgeList() {
+		u, v := edge[0], edge[1]
+		if u > v {
+			u, v = v, u
+		}
+		if seen[pair{u, v}] {
+			continue
+		}
+		seen[pair{u, v}] = true
+
+		s1, s2 := ds.Find(edge[0]), ds.Find(edge[1])
+		if s1 == s2 {
+			return true
+		}
+		ds.Union(s1, s2)
+	}
+
+	return false
+}
+
+// IsCyclicDirected reports whether graph contains a cycle when its edges are read as directed.
+// It is the logical complement of IsDAG, named separately for symmetry with IsCyclicUndirected.
+func IsCyclicDirected(graph Graph) bool {
+	return !IsDAG(graph)
+}
@@ -0,0 +1,85 @@
+package discrete
+
+import "testing"
+
+func sameComponents(t *testing.T, got [][]int, want [][]int) {
+	t.Helper()
+	toSet := func(nodes []int) map[int]bool {
+		s := make(map[int]bool, len(nodes))
+		for _, n := range nodes {
+			s[n] = true
+		}
+		return s
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d components, want %d: got=%v want=%v", len(got), len(want), got, want)
+	}
+	for _, w := range want {
+		ws := toSet(w)
+		found := false
+		for _, g := range got {
+			if len(g) != len(w) {
+				continue
+			}
+			gs := toSet(g)
+			equal := true
+			for n := range ws {
+				if !gs[n] {
+					equal = false
+					break
+				}
+			}
+			if equal {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("component %v not found in %v", w, got)
+		}
+	}
+}
+
+func TestConnectedComponents(t *testing.T) {
+	// 1-2-3 form a component, 4-5 another, and 6 is isolated. The graph is directed and each
+	// edge runs one way only, but weakly connected components should still merge its endpoints.
+	g := newTestGraph([][2]int{{1, 2}, {2, 3}, {4, 5}})
+	g.nodes[6] = true
+
+	got := ConnectedComponents(g)
+	sameComponents(t, got, [][]int{{1, 2, 3}, {4, 5}, {6}})
+}
+
+func TestIsCyclicUndirected(t *testing.T) {
+	tree := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 4}})
+	if IsCyclicUndirected(tree) {
+		t.Error("tree should not be cyclic")
+	}
+
+	withCycle := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	if !IsCyclicUndirected(withCycle) {
+		t.Error("triangle should be cyclic")
+	}
+}
+
+func TestIsCyclicDirected(t *testing.T) {
+	dag := newTestGraph([][2]int{{1, 2}, {2, 3}, {1, 3}})
+	if IsCyclicDirected(dag) {
+		t.Error("DAG should not be cyclic")
+	}
+
+	// 1 -> 2 -> 3 -> 1 is a directed cycle, even though it would also register as one
+	// undirected -- the two functions should agree here.
+	withCycle := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	if !IsCyclicDirected(withCycle) {
+		t.Error("3-cycle should be cyclic")
+	}
+
+	// A back-and-forth pair (1->2, 2->1) is a single connection to IsCyclicUndirected, so not a
+	// cycle there, but it is a directed 2-cycle, since 1 reaches 2 reaches 1.
+	twoCycle := newTestGraph([][2]int{{1, 2}, {2, 1}})
+	if !IsCyclicDirected(twoCycle) {
+		t.Error("2-cycle should be cyclic directed")
+	}
+}
@@ -0,0 +1,127 @@
+package discrete
+
+import "testing"
+
+// chkExampleGraph builds a Cooper/Harvey/Kennedy-style example: a diamond (1 splits into 2 and
+// 3, both rejoining at 4) feeding a loop (4 into 5, which cycles through 6 and 7). It has a
+// join node whose immediate dominator is a strict ancestor of both its predecessors (4) and a
+// loop header that ends up in its own dominance frontier (5), which is what makes it a useful
+// exercise for the algorithm.
+func chkExampleGraph() *testGraph {
+	return newTestGraph([][2]int{
+		{1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 5}, {5, 6}, {5, 7}, {6, 5}, {7, 5},
+	})
+}
+
+func TestImmediateDominators(t *testing.T) {
+	g := chkExampleGraph()
+	idom := ImmediateDominators(1, g)
+
+	want := map[int]int{1: 1, 2: 1, 3: 1, 4: 1, 5: 4, 6: 5, 7: 5}
+	for node, wantIdom := range want {
+		if got := idom[node]; got != wantIdom {
+			t.Errorf("idom[%d] = %d, want %d", node, got, wantIdom)
+		}
+	}
+}
+
+func TestDominatorTree(t *testing.T) {
+	g := chkExampleGraph()
+	idom, children, df := DominatorTree(1, g)
+
+	if idom[5] != 4 {
+		t.Errorf("idom[5] = %d, want 4", idom[5])
+	}
+
+	wantChildrenOf4 := map[int]bool{5: true}
+	gotChildrenOf4 := map[int]bool{}
+	for _, c := range children[4] {
+		gotChildrenOf4[c] = true
+	}
+	if len(gotChildrenOf4) != len(wantChildrenOf4) || !gotChildrenOf4[5] {
+		t.Errorf("children[4] = %v, want [5]", children[4])
+	}
+
+	// 2 and 3 each have a single successor, 4, which they do not dominate -- 4 is on their
+	// dominance frontier.
+	if !df[2].Contains(4) {
+		t.Errorf("df[2] should contain 4, got %v", df[2])
+	}
+	if !df[3].Contains(4) {
+		t.Errorf("df[3] should contain 4, got %v", df[3])
+	}
+
+	// 5, 6 and 7 form a loop with no single dominator among them other than 5 itself, so 5
+	// sits on its own dominance frontier.
+	if !df[5].Contains(5) || !df[6].Contains(5) || !df[7].Contains(5) {
+		t.Errorf("df[5]=%v df[6]=%v df[7]=%v, all should contain 5", df[5], df[6], df[7])
+	}
+}
+
+func TestDominators(t *testing.T) {
+	g := chkExampleGraph()
+	dom := Dominators(1, g)
+
+	// Every node's dominator set must include 1 (the start) and itself.
+	for _, node := range g.NodeList() {
+		if !dom[node].Contains(1) {
+			t.Errorf("dom[%d] should contain start node 1, got %v", node, dom[node])
+		}
+		if !dom[node].Contains(node) {
+			t.Errorf("dom[%d] should contain itself, got %v", node, dom[node])
+		}
+	}
+
+	// 4 dominates 5, 6 and 7, since every path to them passes through it.
+	for _, node := range []int{5, 6, 7} {
+		if !dom[node].Contains(4) {
+			t.Errorf("dom[%d] should contain 4, got %v", node, dom[node])
+		}
+	}
+
+	// 2 does not dominate 3 or vice versa -- they're parallel branches of the diamond.
+	if dom[2].Contains(3) || dom[3].Contains(2) {
+		t.Errorf("dom[2]=%v and dom[3]=%v should not contain each other", dom[2], dom[3])
+	}
+}
+
+func TestDominatorsUnreachableNode(t *testing.T) {
+	// Node 99 has no edges at all, so it can't be reached from start; it must still get an
+	// entry, dominated by every node in the graph -- the old fixed-point behavior.
+	g := chkExampleGraph()
+	g.nodes[99] = true
+
+	dom := Dominators(1, g)
+	got, ok := dom[99]
+	if !ok {
+		t.Fatal("dom[99] is missing an entry for an unreachable node")
+	}
+	for _, node := range g.NodeList() {
+		if !got.Contains(node) {
+			t.Errorf("dom[99] should contain %d, got %v", node, got)
+		}
+	}
+}
+
+func TestPostDominators(t *testing.T) {
+	g := chkExampleGraph()
+	pdom := PostDominators(5, g)
+
+	// Every node that can reach 5 must be post-dominated by 5 itself.
+	for _, node := range []int{1, 2, 3, 4, 6, 7} {
+		if !pdom[node].Contains(5) {
+			t.Errorf("pdom[%d] should contain 5, got %v", node, pdom[node])
+		}
+	}
+
+	// PostDominators(end, g) is the mirror image of Dominators(end, g) with edges reversed.
+	viaReversed := Dominators(5, Reversed{g})
+	for _, node := range g.NodeList() {
+		if _, ok := pdom[node]; !ok {
+			continue
+		}
+		if !Equal(pdom[node], viaReversed[node]) {
+			t.Errorf("pdom[%d]=%v, Dominators(5, Reversed{g})[%d]=%v", node, pdom[node], node, viaReversed[node])
+		}
+	}
+}
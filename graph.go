@@ -79,9 +79,12 @@ func Tarjan(graph Graph) (sccs [][]int) {
 	lowlinks := make(map[int]int, len(nodes))
 	indices := make(map[int]int, len(nodes))
 
-	var strongconnect func(int) []int
+	// strongconnect appends every SCC it completes directly to sccs, rather than returning it,
+	// since a component can finish on a call nested several levels deep in the recursion --
+	// returning it would only hand it to the caller's loop, which never forwarded it onward.
+	var strongconnect func(int)
 
-	strongconnect = func(node int) []int {
+	strongconnect = func(node int) {
 		indices[node] = index
 		lowlinks[node] = index
 		index += 1
@@ -105,17 +108,16 @@ func Tarjan(graph Graph) (sccs [][]int) {
 				stackSet.Remove(v.(int))
 				scc = append(scc, v.(int))
 				if v.(int) == node {
-					return scc
+					break
 				}
 			}
+			sccs = append(sccs, scc)
 		}
-
-		return nil
 	}
 
 	for _, n := range nodes {
 		if _, ok := indices[n]; !ok {
-			sccs = append(sccs, strongconnect(n))
+			strongconnect(n)
 		}
 	}
 
@@ -235,99 +237,181 @@ func Kruskal(dst MutableGraph, graph Graph, Cost func(int, int) float64) {
 
 /* Control flow graph stuff */
 
-// A dominates B if and only if the only path through B travels through A
-//
-// This returns all possible dominators for all nodes, it does not prune for strict dominators, immediate dominators etc
-func Dominators(start int, graph Graph) map[int]*Set {
-	allNodes := NewSet()
-	nlist := graph.NodeList()
-	dominators := make(map[int]*Set, len(nlist))
-	for _, node := range nlist {
-		allNodes.Add(node)
-	}
+// ImmediateDominators computes, for every node reachable from start, the unique node that
+// immediately dominates it, using the Cooper-Harvey-Kennedy iterative algorithm. start is its
+// own immediate dominator.
+func ImmediateDominators(start int, graph Graph) map[int]int {
+	return immediateDominators(start, graph.Successors, graph.Predecessors)
+}
 
-	for _, node := range nlist {
-		dominators[node] = NewSet()
-		if node == start {
-			dominators[node].Add(start)
-		} else {
-			dominators[node].Copy(allNodes)
-		}
-	}
+// immediateDominators is direction-generic so it can compute postdominators too: pass
+// (Successors, Predecessors) for ordinary immediate dominators, or (Predecessors, Successors)
+// for immediate postdominators.
+func immediateDominators(start int, next, prev func(int) []int) map[int]int {
+	rpo, order := reversePostorder(start, next)
+
+	idom := make(map[int]int, len(order))
+	idom[start] = start
 
-	for somethingChanged := true; somethingChanged; {
-		somethingChanged = false
-		for _, node := range nlist {
+	for changed := true; changed; {
+		changed = false
+		for _, node := range order {
 			if node == start {
 				continue
 			}
-			preds := graph.Predecessors(node)
-			if len(preds) == 0 {
+
+			newIdom, resolved := 0, false
+			for _, pred := range prev(node) {
+				if _, ok := idom[pred]; !ok {
+					continue
+				}
+				if !resolved {
+					newIdom, resolved = pred, true
+					continue
+				}
+				newIdom = intersectIdom(idom, rpo, newIdom, pred)
+			}
+
+			if !resolved {
 				continue
 			}
-			tmp := NewSet().Copy(dominators[preds[0]])
-			for _, pred := range preds[1:] {
-				tmp.Intersection(tmp, dominators[pred])
+			if cur, ok := idom[node]; !ok || cur != newIdom {
+				idom[node] = newIdom
+				changed = true
 			}
+		}
+	}
 
-			dom := NewSet()
-			dom.Add(node)
+	return idom
+}
 
-			dom.Union(dom, tmp)
-			if !Equal(dom, dominators[node]) {
-				dominators[node] = dom
-				somethingChanged = true
+// intersectIdom finds the nearest common ancestor of b1 and b2 in the idom tree being built, by
+// walking the two fingers up their idom chains until they meet.
+func intersectIdom(idom, rpo map[int]int, b1, b2 int) int {
+	for b1 != b2 {
+		for rpo[b1] < rpo[b2] {
+			b2 = idom[b2]
+		}
+		for rpo[b2] < rpo[b1] {
+			b1 = idom[b1]
+		}
+	}
+	return b1
+}
+
+// reversePostorder walks the subgraph reachable from start via next, returning each reached
+// node's reverse-post-order number and the nodes themselves in that order (start is order[0]).
+func reversePostorder(start int, next func(int) []int) (rpo map[int]int, order []int) {
+	visited := NewSet()
+	postorder := make([]int, 0)
+
+	var visit func(int)
+	visit = func(node int) {
+		visited.Add(node)
+		for _, succ := range next(node) {
+			if !visited.Contains(succ) {
+				visit(succ)
 			}
 		}
+		postorder = append(postorder, node)
+	}
+	visit(start)
+
+	order = make([]int, len(postorder))
+	rpo = make(map[int]int, len(postorder))
+	for i, node := range postorder {
+		pos := len(postorder) - 1 - i
+		order[pos] = node
+		rpo[node] = pos
 	}
 
-	return dominators
+	return rpo, order
 }
 
-// A Postdominates B if and only if all paths from B travel through A
-//
-// This returns all possible post-dominators for all nodes, it does not prune for strict postdominators, immediate postdominators etc
-func PostDominators(end int, graph Graph) map[int]*Set {
-	allNodes := NewSet()
-	nlist := graph.NodeList()
-	dominators := make(map[int]*Set, len(nlist))
-	for _, node := range nlist {
-		allNodes.Add(node)
-	}
+// DominatorTree computes the immediate dominators, the dominator tree (as a map from each node
+// to the nodes it immediately dominates) and the dominance frontier of every node reachable
+// from start. The dominance frontier of a node n is the set of nodes n dominates a predecessor
+// of but does not strictly dominate (so a loop header can be in its own frontier); it's found by walking, for every join node b, from each
+// predecessor up the idom chain until idom[b] is reached, adding b along the way.
+func DominatorTree(start int, graph Graph) (idom map[int]int, children map[int][]int, df map[int]*Set) {
+	idom = ImmediateDominators(start, graph)
 
-	for _, node := range nlist {
-		dominators[node] = NewSet()
-		if node == end {
-			dominators[node].Add(end)
-		} else {
-			dominators[node].Copy(allNodes)
+	children = make(map[int][]int, len(idom))
+	for node, d := range idom {
+		if node == start {
+			continue
 		}
+		children[d] = append(children[d], node)
 	}
 
-	for somethingChanged := true; somethingChanged; {
-		somethingChanged = false
-		for _, node := range nlist {
-			if node == end {
-				continue
-			}
-			succs := graph.Successors(node)
-			if len(succs) == 0 {
+	df = make(map[int]*Set, len(idom))
+	for node := range idom {
+		df[node] = NewSet()
+	}
+
+	for node := range idom {
+		preds := graph.Predecessors(node)
+		if len(preds) < 2 {
+			continue
+		}
+		for _, pred := range preds {
+			if _, ok := idom[pred]; !ok {
 				continue
 			}
-			tmp := NewSet().Copy(dominators[succs[0]])
-			for _, succ := range succs[1:] {
-				tmp.Intersection(tmp, dominators[succ])
+			for runner := pred; runner != idom[node]; runner = idom[runner] {
+				df[runner].Add(node)
 			}
+		}
+	}
 
-			dom := NewSet()
-			dom.Add(node)
+	return idom, children, df
+}
 
-			dom.Union(dom, tmp)
-			if !Equal(dom, dominators[node]) {
-				dominators[node] = dom
-				somethingChanged = true
+// A dominates B if and only if the only path through B travels through A
+//
+// Dominators returns an entry for every node in graph, built from ImmediateDominators: a
+// reachable node's dominators are just its idom chain back to start, and -- matching the
+// behavior of the fixed-point fomulation this replaced -- a node start can't reach is dominated
+// by every node in the graph.
+func Dominators(start int, graph Graph) map[int]*Set {
+	idom := ImmediateDominators(start, graph)
+	return fillDominators(start, idom, graph.NodeList())
+}
+
+// A Postdominates B if and only if all paths from B travel through A
+//
+// PostDominators returns an entry for every node in graph, found the same way as Dominators but
+// walking the CHK computation backwards from end.
+func PostDominators(end int, graph Graph) map[int]*Set {
+	idom := immediateDominators(end, graph.Predecessors, graph.Successors)
+	return fillDominators(end, idom, graph.NodeList())
+}
+
+// fillDominators turns an idom map into a full dominator set per node, the way Dominators and
+// PostDominators both need it: a node present in idom walks its idom chain back to root: a node
+// absent from idom -- unreachable from root -- is dominated by every node in nodeList, matching
+// what the old fixed-point implementations returned for such nodes.
+func fillDominators(root int, idom map[int]int, nodeList []int) map[int]*Set {
+	dominators := make(map[int]*Set, len(nodeList))
+	for node := range idom {
+		dom := NewSet()
+		for n := node; ; n = idom[n] {
+			dom.Add(n)
+			if n == root {
+				break
 			}
 		}
+		dominators[node] = dom
+	}
+
+	allNodes := NewSet()
+	for _, node := range nodeList {
+		allNodes.Add(node)
+	}
+	for _, node := range nodeList {
+		if _, ok := dominators[node]; !ok {
+			dominators[node] = NewSet().Copy(allNodes)
+		}
 	}
 
 	return dominators
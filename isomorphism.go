@@ -0,0 +1,284 @@
+package discrete
+
+// IsIsomorphic reports whether a and b are isomorphic, ignoring node and edge labels: any two
+// nodes, and any two edges, are considered equal. It is a convenience wrapper around
+// IsomorphicMatching that discards the mapping.
+func IsIsomorphic(a, b Graph) bool {
+	_, ok := IsomorphicMatching(a, b, nil, nil)
+	return ok
+}
+
+// IsomorphicMatching attempts to find a bijection mapping a's nodes onto b's nodes under which
+// a and b have the same edges, using the VF2 algorithm. nodeEq and edgeEq may be nil, meaning
+// "always equal"; supplying them lets a caller require matching node/edge labels on top of
+// pure structural isomorphism, e.g. for CFG or AST equivalence checks.
+//
+// For directed graphs, edge consistency is checked with Predecessors/Successors; for
+// undirected graphs these already coincide per the Graph contract, so the same checks apply
+// without special-casing.
+func IsomorphicMatching(a, b Graph, nodeEq func(aID, bID int) bool, edgeEq func(a1, a2, b1, b2 int) bool) (mapping map[int]int, ok bool) {
+	if len(a.NodeList()) != len(b.NodeList()) {
+		return nil, false
+	}
+	if nodeEq == nil {
+		nodeEq = func(int, int) bool { return true }
+	}
+	if edgeEq == nil {
+		edgeEq = func(int, int, int, int) bool { return true }
+	}
+
+	s := newVF2State(a, b)
+	if s.match(0, nodeEq, edgeEq) {
+		return s.coreA, true
+	}
+	return nil, false
+}
+
+// vf2State carries one VF2 search's partial mapping, core_a/core_b, plus the terminal sets
+// T_in/T_out for each side, keyed to the depth at which a node entered the set so add/remove
+// can restore a previous state by depth instead of recomputing from scratch.
+type vf2State struct {
+	a, b Graph
+
+	coreA map[int]int // a-node -> b-node
+	coreB map[int]int // b-node -> a-node
+
+	outA, inA map[int]int // a-node -> depth at which it entered T_out / T_in
+	outB, inB map[int]int
+
+	aNodes, bNodes []int
+}
+
+func newVF2State(a, b Graph) *vf2State {
+	return &vf2State{
+		a: a, b: b,
+		coreA: make(map[int]int), coreB: make(map[int]int),
+		outA: make(map[int]int), inA: make(map[int]int),
+		outB: make(map[int]int), inB: make(map[int]int),
+		aNodes: a.NodeList(), bNodes: b.NodeList(),
+	}
+}
+
+// match tries to extend the current partial mapping to cover every a-node, backtracking over
+// each candidate pair in turn.
+func (s *vf2State) match(depth int, nodeEq func(int, int) bool, edgeEq func(int, int, int, int) bool) bool {
+	if len(s.coreA) == len(s.aNodes) {
+		return true
+	}
+
+	for _, pair := range s.candidatePairs() {
+		an, bn := pair[0], pair[1]
+		if !s.feasible(an, bn, nodeEq, edgeEq) {
+			continue
+		}
+
+		// add drops an and bn from the terminal sets they already belonged to; keep their
+		// entries so they can be put back once remove has cleared this depth's own stamps.
+		terminals := [4]map[int]int{s.outA, s.inA, s.outB, s.inB}
+		members := [4]int{an, an, bn, bn}
+		var stamps [4]int
+		var had [4]bool
+		for i, terminal := range terminals {
+			stamps[i], had[i] = terminal[members[i]]
+		}
+
+		s.add(an, bn, depth+1)
+		if s.match(depth+1, nodeEq, edgeEq) {
+			return true
+		}
+		s.remove(an, bn, depth+1)
+
+		for i, terminal := range terminals {
+			if had[i] {
+				terminal[members[i]] = stamps[i]
+			}
+		}
+	}
+
+	return false
+}
+
+// candidatePairs picks the next set of (a, b) pairs to try: both nodes from T_out if non-empty
+// on both sides, else both from T_in, else all remaining unmapped nodes. Only the smallest
+// unmapped a-node is paired against every candidate b-node -- standard VF2 pruning.
+func (s *vf2State) candidatePairs() [][2]int {
+	if aOut, bOut := keys(s.outA), keys(s.outB); len(aOut) > 0 && len(bOut) > 0 {
+		return pairWithMin(aOut, bOut)
+	}
+	if aIn, bIn := keys(s.inA), keys(s.inB); len(aIn) > 0 && len(bIn) > 0 {
+		return pairWithMin(aIn, bIn)
+	}
+	return pairWithMin(unmapped(s.aNodes, s.coreA), unmapped(s.bNodes, s.coreB))
+}
+
+func pairWithMin(aCandidates, bCandidates []int) [][2]int {
+	min := aCandidates[0]
+	for _, n := range aCandidates[1:] {
+		if n < min {
+			min = n
+		}
+	}
+
+	pairs := make([][2]int, 0, len(bCandidates))
+	for _, bn := range bCandidates {
+		pairs = append(pairs, [2]int{min, bn})
+	}
+	return pairs
+}
+
+func keys(m map[int]int) []int {
+	out := make([]int, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+func unmapped(nodes []int, core map[int]int) []int {
+	out := make([]int, 0, len(nodes))
+	for _, node := range nodes {
+		if _, ok := core[node]; !ok {
+			out = append(out, node)
+		}
+	}
+	return out
+}
+
+// feasible runs the VF2 feasibility rules for adding (an, bn) to the current mapping:
+// consistency of an's and bn's already-mapped neighbors (R_pred/R_succ), then the 1-look-ahead
+// and 2-look-ahead cardinality checks on the terminal sets.
+func (s *vf2State) feasible(an, bn int, nodeEq func(int, int) bool, edgeEq func(int, int, int, int) bool) bool {
+	if !nodeEq(an, bn) {
+		return false
+	}
+
+	aSucc, aPred := s.a.Successors(an), s.a.Predecessors(an)
+	bSucc, bPred := s.b.Successors(bn), s.b.Predecessors(bn)
+
+	for _, asn := range aSucc {
+		if bm, ok := s.coreA[asn]; ok {
+			if !s.b.IsSuccessor(bn, bm) || !edgeEq(an, asn, bn, bm) {
+				return false
+			}
+		}
+	}
+	for _, apn := range aPred {
+		if bm, ok := s.coreA[apn]; ok {
+			if !s.b.IsPredecessor(bn, bm) || !edgeEq(apn, an, bm, bn) {
+				return false
+			}
+		}
+	}
+	for _, bsn := range bSucc {
+		if _, ok := s.coreB[bsn]; ok && !s.a.IsSuccessor(an, s.coreB[bsn]) {
+			return false
+		}
+	}
+	for _, bpn := range bPred {
+		if _, ok := s.coreB[bpn]; ok && !s.a.IsPredecessor(an, s.coreB[bpn]) {
+			return false
+		}
+	}
+
+	if countIn(aSucc, s.outA) != countIn(bSucc, s.outB) {
+		return false
+	}
+	if countIn(aPred, s.inA) != countIn(bPred, s.inB) {
+		return false
+	}
+
+	if countNew(aSucc, aPred, s.coreA, s.outA, s.inA) != countNew(bSucc, bPred, s.coreB, s.outB, s.inB) {
+		return false
+	}
+
+	return true
+}
+
+func countIn(neighbors []int, terminal map[int]int) int {
+	count := 0
+	for _, n := range neighbors {
+		if _, ok := terminal[n]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// countNew counts the neighbors (successors and predecessors, deduplicated) outside the mapping
+// and both terminal sets -- the 2-look-ahead rule, rejecting a pairing that would grow the
+// unexplored frontier by different amounts on each side.
+func countNew(succ, pred []int, core, out, in map[int]int) int {
+	seen := make(map[int]bool, len(succ)+len(pred))
+	count := 0
+	add := func(n int) {
+		if seen[n] {
+			return
+		}
+		seen[n] = true
+		if _, ok := core[n]; ok {
+			return
+		}
+		if _, ok := out[n]; ok {
+			return
+		}
+		if _, ok := in[n]; ok {
+			return
+		}
+		count++
+	}
+	for _, n := range succ {
+		add(n)
+	}
+	for _, n := range pred {
+		add(n)
+	}
+	return count
+}
+
+// add commits (an, bn) to the mapping and extends both sides' terminal sets with any new
+// neighbors, stamped with depth so remove can undo exactly this step.
+func (s *vf2State) add(an, bn, depth int) {
+	s.coreA[an] = bn
+	s.coreB[bn] = an
+
+	delete(s.outA, an)
+	delete(s.inA, an)
+	delete(s.outB, bn)
+	delete(s.inB, bn)
+
+	extend(s.a.Successors(an), s.coreA, s.outA, depth)
+	extend(s.a.Predecessors(an), s.coreA, s.inA, depth)
+	extend(s.b.Successors(bn), s.coreB, s.outB, depth)
+	extend(s.b.Predecessors(bn), s.coreB, s.inB, depth)
+}
+
+func extend(neighbors []int, core, terminal map[int]int, depth int) {
+	for _, n := range neighbors {
+		if _, ok := core[n]; ok {
+			continue
+		}
+		if _, ok := terminal[n]; !ok {
+			terminal[n] = depth
+		}
+	}
+}
+
+// remove undoes exactly the changes add made at depth: it unmaps (an, bn) and drops every
+// terminal-set entry stamped with that depth, so an earlier depth's entries are left untouched.
+func (s *vf2State) remove(an, bn, depth int) {
+	delete(s.coreA, an)
+	delete(s.coreB, bn)
+
+	shrink(s.outA, depth)
+	shrink(s.inA, depth)
+	shrink(s.outB, depth)
+	shrink(s.inB, depth)
+}
+
+func shrink(terminal map[int]int, depth int) {
+	for node, d := range terminal {
+		if d == depth {
+			delete(terminal, node)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package discrete
+
+import "testing"
+
+func checkMapping(t *testing.T, a, b Graph, mapping map[int]int) {
+	t.Helper()
+	for _, edge := range a.EdgeList() {
+		u, ok1 := mapping[edge[0]]
+		v, ok2 := mapping[edge[1]]
+		if !ok1 || !ok2 || !b.IsSuccessor(u, v) {
+			t.Errorf("mapping does not preserve edge %v (maps to %d->%d)", edge, u, v)
+		}
+	}
+}
+
+func TestIsIsomorphicSquares(t *testing.T) {
+	// Two 4-cycles with different labels are isomorphic.
+	a := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}})
+	b := newUndirectedTestGraph([][2]int{{10, 20}, {20, 30}, {30, 40}, {40, 10}})
+
+	if !IsIsomorphic(a, b) {
+		t.Error("two 4-cycles should be isomorphic")
+	}
+}
+
+func TestIsIsomorphicDifferentSize(t *testing.T) {
+	a := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	b := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}})
+
+	if IsIsomorphic(a, b) {
+		t.Error("a triangle and a 4-cycle should not be isomorphic")
+	}
+}
+
+func TestIsIsomorphicDegreeSequenceMismatch(t *testing.T) {
+	// A 4-cycle (all degree 2) vs a star (one degree-3 hub, three degree-1 leaves): same node
+	// count, but different degree sequences (and 4 edges against 3), so not isomorphic.
+	cycle := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}})
+	star := newUndirectedTestGraph([][2]int{{1, 2}, {1, 3}, {1, 4}})
+
+	if IsIsomorphic(cycle, star) {
+		t.Error("a 4-cycle and a star should not be isomorphic")
+	}
+}
+
+func TestIsomorphicMatchingReturnsValidMapping(t *testing.T) {
+	a := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	b := newUndirectedTestGraph([][2]int{{7, 8}, {8, 9}, {9, 7}})
+
+	mapping, ok := IsomorphicMatching(a, b, nil, nil)
+	if !ok {
+		t.Fatal("expected a and b to match")
+	}
+	checkMapping(t, a, b, mapping)
+}
+
+func TestIsIsomorphicDirectedRelabeled(t *testing.T) {
+	a := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	b := newTestGraph([][2]int{{7, 8}, {8, 9}, {9, 7}})
+
+	if !IsIsomorphic(a, b) {
+		t.Error("a relabeled directed 3-cycle should be isomorphic")
+	}
+}
+
+func TestIsIsomorphicDirectedReversedEdge(t *testing.T) {
+	// Same nodes and the same edges as an undirected graph, but one edge runs the other way:
+	// 1->2->3->1 vs 1->2->3, 1->3. Undirected these are both triangles, but directed one has a
+	// 3-cycle and the other doesn't, so they must not match.
+	cycle := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	notCycle := newTestGraph([][2]int{{1, 2}, {2, 3}, {1, 3}})
+
+	if IsIsomorphic(cycle, notCycle) {
+		t.Error("a directed 3-cycle should not be isomorphic to the same edges with one reversed")
+	}
+}
+
+func TestIsomorphicMatchingNodeEq(t *testing.T) {
+	a := newUndirectedTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	b := newUndirectedTestGraph([][2]int{{7, 8}, {8, 9}, {9, 7}})
+
+	// A nodeEq that refuses to ever match should fail even for structurally identical graphs.
+	never := func(aID, bID int) bool { return false }
+	if _, ok := IsomorphicMatching(a, b, never, nil); ok {
+		t.Error("expected no match when nodeEq always rejects")
+	}
+}
@@ -0,0 +1,84 @@
+package discrete
+
+import "sync"
+
+// AllSimplePaths enumerates every simple path (no repeated nodes) from "from" to "to" in
+// graph. The search extends a path through at most cutoff nodes before "to" is appended, so a
+// returned path holds at most cutoff+1 nodes. cutoff <= 0 means unbounded, i.e. bounded only by
+// len(graph.NodeList()).
+func AllSimplePaths(graph Graph, from, to, cutoff int) [][]int {
+	ch, cancel := AllSimplePathsIter(graph, from, to, cutoff)
+	defer cancel()
+
+	paths := make([][]int, 0)
+	for path := range ch {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// AllSimplePathsIter is the channel-based counterpart to AllSimplePaths, for callers with a
+// fan-out too large to comfortably buffer: it streams each path as it is discovered and closes
+// the returned channel once the search is exhausted. The returned cancel func stops the search
+// and must be called once the caller is done reading -- including when it stops ranging over
+// the channel early -- so the background goroutine isn't left blocked on a send forever.
+//
+// The search maintains a stack of successor iterators, one per node on the current path, plus
+// a set of visited nodes. At each step it advances the iterator on top of the stack: a
+// successor equal to "to" yields a copy of the current path with "to" appended; an unvisited
+// successor within the cutoff is pushed, along with its own successor iterator; and once an
+// iterator is exhausted its node is popped and unmarked so other paths can revisit it.
+func AllSimplePathsIter(graph Graph, from, to, cutoff int) (paths <-chan []int, cancel func()) {
+	out := make(chan []int)
+	done := make(chan struct{})
+	var once sync.Once
+	cancel = func() { once.Do(func() { close(done) }) }
+
+	if cutoff <= 0 {
+		cutoff = len(graph.NodeList())
+	}
+
+	go func() {
+		defer close(out)
+
+		visited := NewSet()
+		visited.Add(from)
+		path := []int{from}
+		stack := [][]int{graph.Successors(from)}
+
+		for len(stack) > 0 {
+			children := stack[len(stack)-1]
+			if len(children) == 0 {
+				stack = stack[:len(stack)-1]
+				visited.Remove(path[len(path)-1])
+				path = path[:len(path)-1]
+				continue
+			}
+
+			next := children[0]
+			stack[len(stack)-1] = children[1:]
+
+			if next == to {
+				found := make([]int, len(path)+1)
+				copy(found, path)
+				found[len(path)] = to
+				select {
+				case out <- found:
+				case <-done:
+					return
+				}
+				continue
+			}
+
+			if visited.Contains(next) || len(path) >= cutoff {
+				continue
+			}
+
+			visited.Add(next)
+			path = append(path, next)
+			stack = append(stack, graph.Successors(next))
+		}
+	}()
+
+	return out, cancel
+}
@@ -0,0 +1,48 @@
+package discrete
+
+import "testing"
+
+func TestAllSimplePaths(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}, {2, 3}, {1, 3}, {3, 4}})
+
+	paths := AllSimplePaths(g, 1, 3, 0)
+	if len(paths) != 2 {
+		t.Fatalf("got %d paths from 1 to 3, want 2: %v", len(paths), paths)
+	}
+	for _, p := range paths {
+		if !IsPath(p, g) {
+			t.Errorf("AllSimplePaths returned a non-path: %v", p)
+		}
+		if p[0] != 1 || p[len(p)-1] != 3 {
+			t.Errorf("path %v does not run from 1 to 3", p)
+		}
+	}
+}
+
+func TestAllSimplePathsCutoff(t *testing.T) {
+	// 1 -> 2 -> 3 -> 4 -> 5 is the only path from 1 to 5, but it needs 5 nodes; a cutoff of 3
+	// should rule it out.
+	g := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}})
+
+	if paths := AllSimplePaths(g, 1, 5, 3); len(paths) != 0 {
+		t.Errorf("expected no paths within cutoff 3, got %v", paths)
+	}
+	if paths := AllSimplePaths(g, 1, 5, 5); len(paths) != 1 {
+		t.Errorf("expected exactly one path within cutoff 5, got %v", paths)
+	}
+}
+
+func TestAllSimplePathsIterCancel(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}})
+
+	ch, cancel := AllSimplePathsIter(g, 1, 4, 0)
+	if _, ok := <-ch; !ok {
+		t.Fatal("expected at least one path before cancelling")
+	}
+	cancel()
+
+	// Draining (or abandoning) the channel after cancel must not block forever; a background
+	// goroutine stuck on a send would otherwise leak.
+	for range ch {
+	}
+}
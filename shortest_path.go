@@ -0,0 +1,203 @@
+package discrete
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// ErrNegativeEdge is returned by Dijkstra, DijkstraFrom and AStar (and so by ShortestPath) when
+// a traversed edge has a negative cost; Dijkstra's algorithm, which A* generalizes, is only
+// correct for non-negative edge weights.
+var ErrNegativeEdge = errors.New("discrete: negative edge weight")
+
+// errNoPath is returned by AStar when goal is not reachable from source.
+var errNoPath = errors.New("discrete: no path between source and goal")
+
+// heapEntry is a node paired with its priority in a nodeHeap.
+type heapEntry struct {
+	node     int
+	priority float64
+}
+
+// nodeHeap is a binary heap of heapEntry keyed on priority, used by Dijkstra and AStar as the
+// tentative-distance frontier.
+type nodeHeap []heapEntry
+
+func (h nodeHeap) Len() int            { return len(h) }
+func (h nodeHeap) Less(i, j int) bool  { return h[i].priority < h[j].priority }
+func (h nodeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x interface{}) { *h = append(*h, x.(heapEntry)) }
+func (h *nodeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	*h = old[:n-1]
+	return entry
+}
+
+// dijkstraSearch is the shared core of Dijkstra and DijkstraFrom: a binary-heap Dijkstra search
+// from source that returns the distance and predecessor maps for every node reached, or
+// ErrNegativeEdge the first time it sees a negative-cost edge.
+//
+// As with other algorithms that use Cost, the order of precedence is Argument > Interface > UniformCost
+func dijkstraSearch(source int, graph Graph, cost func(int, int) float64) (dist map[int]float64, prev map[int]int, err error) {
+	if cost == nil {
+		if cgraph, ok := graph.(Coster); ok {
+			cost = cgraph.Cost
+		} else {
+			cost = UniformCost
+		}
+	}
+
+	dist = map[int]float64{source: 0}
+	prev = make(map[int]int)
+	visited := NewSet()
+
+	pq := &nodeHeap{{source, 0}}
+	for pq.Len() > 0 {
+		entry := heap.Pop(pq).(heapEntry)
+		node := entry.node
+		if visited.Contains(node) {
+			continue
+		}
+		visited.Add(node)
+
+		for _, succ := range graph.Successors(node) {
+			w := cost(node, succ)
+			if w < 0 {
+				return nil, nil, ErrNegativeEdge
+			}
+			next := dist[node] + w
+			if d, ok := dist[succ]; !ok || next < d {
+				dist[succ] = next
+				prev[succ] = node
+				heap.Push(pq, heapEntry{succ, next})
+			}
+		}
+	}
+
+	return dist, prev, nil
+}
+
+// DijkstraFrom runs dijkstraSearch from source once and returns two closures over its result:
+// Reachable reports the distance to a node and whether it was reached at all, and PathTo
+// reconstructs the shortest path to a node (as a slice from source to node, inclusive) from the
+// predecessor map the search already built. This lets a caller query many targets without
+// recomputing the search for each one.
+func DijkstraFrom(source int, graph Graph, cost func(int, int) float64) (Reachable func(int) (float64, bool), PathTo func(int) []int, err error) {
+	dist, prev, err := dijkstraSearch(source, graph, cost)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	Reachable = func(node int) (float64, bool) {
+		d, ok := dist[node]
+		return d, ok
+	}
+
+	PathTo = func(node int) []int {
+		if _, ok := dist[node]; !ok {
+			return nil
+		}
+		path := []int{node}
+		for node != source {
+			p, ok := prev[node]
+			if !ok {
+				break
+			}
+			path = append(path, p)
+			node = p
+		}
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+		return path
+	}
+
+	return Reachable, PathTo, nil
+}
+
+// Dijkstra computes single-source shortest paths from source to every node reachable from it.
+// dist maps each reachable node to its distance from source, and prev maps each reachable node
+// (other than source) to its predecessor on a shortest path.
+func Dijkstra(source int, graph Graph, cost func(int, int) float64) (dist map[int]float64, prev map[int]int, err error) {
+	return dijkstraSearch(source, graph, cost)
+}
+
+// AStar finds a shortest path from source to goal. It falls back to HeuristicCoster's
+// HeuristicCost when heuristic is nil and graph implements HeuristicCoster, and to the null
+// heuristic -- making the search equivalent to Dijkstra -- otherwise. Negative edge weights are
+// rejected with ErrNegativeEdge, since they break A*'s (and Dijkstra's) correctness guarantees.
+//
+// As with other algorithms that use Cost, the order of precedence is Argument > Interface > UniformCost
+func AStar(source, goal int, graph Graph, cost, heuristic func(int, int) float64) (path []int, weight float64, err error) {
+	if cost == nil {
+		if cgraph, ok := graph.(Coster); ok {
+			cost = cgraph.Cost
+		} else {
+			cost = UniformCost
+		}
+	}
+	if heuristic == nil {
+		if hgraph, ok := graph.(HeuristicCoster); ok {
+			heuristic = hgraph.HeuristicCost
+		} else {
+			heuristic = NullHeuristic
+		}
+	}
+
+	dist := map[int]float64{source: 0}
+	prev := make(map[int]int)
+	visited := NewSet()
+
+	pq := &nodeHeap{{source, heuristic(source, goal)}}
+	for pq.Len() > 0 {
+		entry := heap.Pop(pq).(heapEntry)
+		node := entry.node
+		if visited.Contains(node) {
+			continue
+		}
+		if node == goal {
+			break
+		}
+		visited.Add(node)
+
+		for _, succ := range graph.Successors(node) {
+			w := cost(node, succ)
+			if w < 0 {
+				return nil, 0, ErrNegativeEdge
+			}
+			next := dist[node] + w
+			if d, ok := dist[succ]; !ok || next < d {
+				dist[succ] = next
+				prev[succ] = node
+				heap.Push(pq, heapEntry{succ, next + heuristic(succ, goal)})
+			}
+		}
+	}
+
+	if _, ok := dist[goal]; !ok {
+		return nil, 0, errNoPath
+	}
+
+	path = []int{goal}
+	for node := goal; node != source; {
+		p, ok := prev[node]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+		node = p
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, dist[goal], nil
+}
+
+// ShortestPath is a convenience wrapper for the common case of wanting the shortest path
+// between two nodes without supplying custom cost or heuristic functions.
+func ShortestPath(source, goal int, graph Graph) (path []int, weight float64, err error) {
+	return AStar(source, goal, graph, nil, nil)
+}
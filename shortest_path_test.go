@@ -0,0 +1,103 @@
+package discrete
+
+import (
+	"reflect"
+	"testing"
+)
+
+type weightedTestGraph struct {
+	*testGraph
+	weight map[[2]int]float64
+}
+
+func newWeightedTestGraph(edges [][2]int, weight map[[2]int]float64) *weightedTestGraph {
+	return &weightedTestGraph{testGraph: newTestGraph(edges), weight: weight}
+}
+
+func (g *weightedTestGraph) Cost(node1, node2 int) float64 { return g.weight[[2]int{node1, node2}] }
+
+func TestDijkstra(t *testing.T) {
+	g := newWeightedTestGraph(
+		[][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}},
+		map[[2]int]float64{{1, 2}: 1, {1, 3}: 5, {2, 4}: 1, {3, 4}: 1},
+	)
+
+	dist, prev, err := Dijkstra(1, g, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dist[4] != 2 {
+		t.Errorf("dist[4] = %v, want 2 (via 1->2->4)", dist[4])
+	}
+	if prev[4] != 2 {
+		t.Errorf("prev[4] = %v, want 2", prev[4])
+	}
+}
+
+func TestDijkstraNegativeEdge(t *testing.T) {
+	g := newWeightedTestGraph([][2]int{{1, 2}}, map[[2]int]float64{{1, 2}: -1})
+
+	if _, _, err := Dijkstra(1, g, nil); err != ErrNegativeEdge {
+		t.Errorf("Dijkstra with a negative edge: got err %v, want ErrNegativeEdge", err)
+	}
+}
+
+func TestDijkstraFrom(t *testing.T) {
+	g := newWeightedTestGraph(
+		[][2]int{{1, 2}, {2, 3}, {1, 3}},
+		map[[2]int]float64{{1, 2}: 1, {2, 3}: 1, {1, 3}: 5},
+	)
+
+	reachable, pathTo, err := DijkstraFrom(1, g, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d, ok := reachable(3); !ok || d != 2 {
+		t.Errorf("reachable(3) = (%v, %v), want (2, true)", d, ok)
+	}
+	if _, ok := reachable(99); ok {
+		t.Error("reachable(99) should report false for a nonexistent node")
+	}
+
+	want := []int{1, 2, 3}
+	if got := pathTo(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("pathTo(3) = %v, want %v", got, want)
+	}
+}
+
+func TestDijkstraFromNegativeEdge(t *testing.T) {
+	g := newWeightedTestGraph([][2]int{{1, 2}}, map[[2]int]float64{{1, 2}: -1})
+
+	if _, _, err := DijkstraFrom(1, g, nil); err != ErrNegativeEdge {
+		t.Errorf("DijkstraFrom with a negative edge: got err %v, want ErrNegativeEdge", err)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := newWeightedTestGraph(
+		[][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}},
+		map[[2]int]float64{{1, 2}: 1, {1, 3}: 1, {2, 4}: 5, {3, 4}: 1},
+	)
+
+	path, weight, err := ShortestPath(1, 4, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if weight != 2 {
+		t.Errorf("weight = %v, want 2 (via 1->3->4)", weight)
+	}
+	want := []int{1, 3, 4}
+	if !reflect.DeepEqual(path, want) {
+		t.Errorf("path = %v, want %v", path, want)
+	}
+}
+
+func TestShortestPathNoPath(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}})
+	g.nodes[3] = true
+
+	if _, _, err := ShortestPath(1, 3, g); err != errNoPath {
+		t.Errorf("got err %v, want errNoPath", err)
+	}
+}
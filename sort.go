@@ -0,0 +1,199 @@
+package discrete
+
+import "fmt"
+
+// CycleError is returned by TopologicalSort and KahnSort when graph is not a DAG. Nodes holds
+// one strongly connected component (as found by Tarjan) responsible for the cycle, and
+// ExampleEdge is one edge within that component a caller can report to a user.
+type CycleError struct {
+	Nodes       []int
+	ExampleEdge [2]int
+}
+
+func (e CycleError) Error() string {
+	return fmt.Sprintf("discrete: graph contains a cycle among %v (e.g. edge %v)", e.Nodes, e.ExampleEdge)
+}
+
+// TopologicalSort orders the nodes of graph so that every edge points from an earlier node to
+// a later one. It runs a DFS from every unvisited node, emitting nodes in reverse order of
+// finishing time; if a back edge is found (an edge into a node still on the current DFS stack)
+// graph is not a DAG and a CycleError is returned instead, built from the strongly connected
+// component Tarjan finds containing the back edge.
+func TopologicalSort(graph Graph) ([]int, error) {
+	const (
+		white = iota
+		gray
+		black
+	)
+
+	color := make(map[int]int, len(graph.NodeList()))
+	order := make([]int, 0, len(graph.NodeList()))
+	var backEdge [2]int
+
+	var visit func(int) bool
+	visit = func(node int) bool {
+		color[node] = gray
+		for _, succ := range graph.Successors(node) {
+			switch color[succ] {
+			case white:
+				if !visit(succ) {
+					return false
+				}
+			case gray:
+				backEdge = [2]int{node, succ}
+				return false
+			}
+		}
+		color[node] = black
+		order = append(order, node)
+		return true
+	}
+
+	for _, node := range graph.NodeList() {
+		if color[node] == white {
+			if !visit(node) {
+				return nil, cycleErrorFor(graph, backEdge)
+			}
+		}
+	}
+
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// KahnSort orders the nodes of graph so that every edge points from an earlier node to a later
+// one, using Kahn's algorithm: it seeds a queue with every zero-in-degree node and repeatedly
+// dequeues a node, emitting it and decrementing the in-degree of its successors, queueing any
+// that drop to zero. If nodes remain un-queued once the queue drains, graph is not a DAG and a
+// CycleError is returned, built from the strongly connected component Tarjan finds among the
+// leftover nodes.
+func KahnSort(graph Graph) ([]int, error) {
+	nlist := graph.NodeList()
+	inDegree := make(map[int]int, len(nlist))
+	for _, node := range nlist {
+		inDegree[node] = len(graph.Predecessors(node))
+	}
+
+	queue := make([]int, 0, len(nlist))
+	for _, node := range nlist {
+		if inDegree[node] == 0 {
+			queue = append(queue, node)
+		}
+	}
+
+	order := make([]int, 0, len(nlist))
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		order = append(order, node)
+
+		for _, succ := range graph.Successors(node) {
+			inDegree[succ]--
+			if inDegree[succ] == 0 {
+				queue = append(queue, succ)
+			}
+		}
+	}
+
+	if len(order) != len(nlist) {
+		return nil, cyclicRemainderError(graph, order)
+	}
+	return order, nil
+}
+
+// IsDAG reports whether graph is acyclic. Unlike TopologicalSort it short-circuits on the
+// first back edge it finds instead of computing a full ordering.
+func IsDAG(graph Graph) bool {
+	const (
+		white = iota
+		gray
+		black
+	)
+
+	color := make(map[int]int, len(graph.NodeList()))
+
+	var visit func(int) bool
+	visit = func(node int) bool {
+		color[node] = gray
+		for _, succ := range graph.Successors(node) {
+			switch color[succ] {
+			case white:
+				if !visit(succ) {
+					return false
+				}
+			case gray:
+				return false
+			}
+		}
+		color[node] = black
+		return true
+	}
+
+	for _, node := range graph.NodeList() {
+		if color[node] == white && !visit(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// cycleErrorFor builds a CycleError for a back edge found during a DFS, identifying the
+// strongly connected component the back edge's source belongs to.
+func cycleErrorFor(graph Graph, backEdge [2]int) error {
+	for _, scc := range Tarjan(graph) {
+		for _, node := range scc {
+			if node == backEdge[0] {
+				return CycleError{Nodes: scc, ExampleEdge: backEdge}
+			}
+		}
+	}
+	return CycleError{Nodes: []int{backEdge[0], backEdge[1]}, ExampleEdge: backEdge}
+}
+
+// cyclicRemainderError builds a CycleError from the nodes KahnSort could not order, by finding
+// the strongly connected component among them that actually forms the cycle.
+func cyclicRemainderError(graph Graph, ordered []int) error {
+	done := NewSet()
+	for _, node := range ordered {
+		done.Add(node)
+	}
+
+	for _, scc := range Tarjan(graph) {
+		inRemainder := false
+		for _, node := range scc {
+			if !done.Contains(node) {
+				inRemainder = true
+				break
+			}
+		}
+		if !inRemainder {
+			continue
+		}
+		if len(scc) > 1 {
+			return CycleError{Nodes: scc, ExampleEdge: sccEdge(graph, scc)}
+		}
+		if node := scc[0]; graph.IsSuccessor(node, node) {
+			return CycleError{Nodes: scc, ExampleEdge: [2]int{node, node}}
+		}
+	}
+	return CycleError{Nodes: []int{}, ExampleEdge: [2]int{}}
+}
+
+// sccEdge returns one real edge between two members of scc, which Tarjan guarantees exists
+// whenever len(scc) > 1, by scanning each member's successors for another member.
+func sccEdge(graph Graph, scc []int) [2]int {
+	member := make(map[int]bool, len(scc))
+	for _, node := range scc {
+		member[node] = true
+	}
+	for _, u := range scc {
+		for _, v := range graph.Successors(u) {
+			if member[v] {
+				return [2]int{u, v}
+			}
+		}
+	}
+	return [2]int{scc[0], scc[0]}
+}
@@ -0,0 +1,107 @@
+package discrete
+
+import "testing"
+
+func indexOf(order []int, node int) int {
+	for i, n := range order {
+		if n == node {
+			return i
+		}
+	}
+	return -1
+}
+
+func checkTopoOrder(t *testing.T, order []int, edges [][2]int) {
+	t.Helper()
+	for _, e := range edges {
+		if indexOf(order, e[0]) >= indexOf(order, e[1]) {
+			t.Errorf("order %v does not place %d before %d", order, e[0], e[1])
+		}
+	}
+}
+
+func TestTopologicalSortDAG(t *testing.T) {
+	edges := [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}}
+	g := newTestGraph(edges)
+
+	order, err := TopologicalSort(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTopoOrder(t, order, edges)
+}
+
+func TestTopologicalSortCycle(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+
+	_, err := TopologicalSort(g)
+	cycleErr, ok := err.(CycleError)
+	if !ok {
+		t.Fatalf("expected a CycleError, got %v", err)
+	}
+	if !g.IsSuccessor(cycleErr.ExampleEdge[0], cycleErr.ExampleEdge[1]) {
+		t.Errorf("ExampleEdge %v is not a real edge", cycleErr.ExampleEdge)
+	}
+	if len(cycleErr.Nodes) != 3 {
+		t.Errorf("Nodes = %v, want all 3 nodes of the cycle", cycleErr.Nodes)
+	}
+}
+
+func TestKahnSortDAG(t *testing.T) {
+	edges := [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}}
+	g := newTestGraph(edges)
+
+	order, err := KahnSort(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTopoOrder(t, order, edges)
+}
+
+func TestKahnSortCycle(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+
+	_, err := KahnSort(g)
+	cycleErr, ok := err.(CycleError)
+	if !ok {
+		t.Fatalf("expected a CycleError, got %v", err)
+	}
+	if !g.IsSuccessor(cycleErr.ExampleEdge[0], cycleErr.ExampleEdge[1]) {
+		t.Errorf("ExampleEdge %v is not a real edge", cycleErr.ExampleEdge)
+	}
+	if len(cycleErr.Nodes) != 3 {
+		t.Errorf("Nodes = %v, want all 3 nodes of the cycle", cycleErr.Nodes)
+	}
+}
+
+func TestKahnSortCycleWithDAGRemainder(t *testing.T) {
+	// 1 -> 2 feeds into the cycle 3 -> 4 -> 3; 1 and 2 sort fine, but the cycle should still be
+	// reported correctly, without 1 or 2 showing up in it.
+	g := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 3}})
+
+	_, err := KahnSort(g)
+	cycleErr, ok := err.(CycleError)
+	if !ok {
+		t.Fatalf("expected a CycleError, got %v", err)
+	}
+	for _, node := range cycleErr.Nodes {
+		if node == 1 || node == 2 {
+			t.Errorf("Nodes %v should not include the acyclic prefix 1 or 2", cycleErr.Nodes)
+		}
+	}
+	if !g.IsSuccessor(cycleErr.ExampleEdge[0], cycleErr.ExampleEdge[1]) {
+		t.Errorf("ExampleEdge %v is not a real edge", cycleErr.ExampleEdge)
+	}
+}
+
+func TestIsDAG(t *testing.T) {
+	dag := newTestGraph([][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}})
+	if !IsDAG(dag) {
+		t.Error("expected dag to be reported as a DAG")
+	}
+
+	cyclic := newTestGraph([][2]int{{1, 2}, {2, 3}, {3, 1}})
+	if IsDAG(cyclic) {
+		t.Error("expected cyclic graph to not be reported as a DAG")
+	}
+}
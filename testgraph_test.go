@@ -0,0 +1,83 @@
+package discrete
+
+// testGraph is a minimal Graph implementation built from an edge list, used across this
+// package's tests. It is directed unless built with newUndirectedTestGraph.
+type testGraph struct {
+	directed bool
+	nodes    map[int]bool
+	succ     map[int][]int
+	pred     map[int][]int
+}
+
+func newTestGraph(edges [][2]int) *testGraph {
+	return buildTestGraph(true, edges)
+}
+
+func newUndirectedTestGraph(edges [][2]int) *testGraph {
+	return buildTestGraph(false, edges)
+}
+
+func buildTestGraph(directed bool, edges [][2]int) *testGraph {
+	g := &testGraph{directed: directed, nodes: map[int]bool{}, succ: map[int][]int{}, pred: map[int][]int{}}
+	add := func(u, v int) {
+		g.nodes[u] = true
+		g.nodes[v] = true
+		g.succ[u] = append(g.succ[u], v)
+		g.pred[v] = append(g.pred[v], u)
+	}
+	for _, e := range edges {
+		add(e[0], e[1])
+		if !directed {
+			add(e[1], e[0])
+		}
+	}
+	return g
+}
+
+func (g *testGraph) Successors(node int) []int   { return g.succ[node] }
+func (g *testGraph) Predecessors(node int) []int { return g.pred[node] }
+
+func (g *testGraph) IsSuccessor(node, successor int) bool {
+	for _, s := range g.succ[node] {
+		if s == successor {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *testGraph) IsPredecessor(node, predecessor int) bool {
+	for _, p := range g.pred[node] {
+		if p == predecessor {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *testGraph) IsAdjacent(node, neighbor int) bool {
+	return g.IsSuccessor(node, neighbor) || g.IsPredecessor(node, neighbor)
+}
+
+func (g *testGraph) NodeExists(node int) bool { return g.nodes[node] }
+func (g *testGraph) Degree(node int) int      { return len(g.succ[node]) + len(g.pred[node]) }
+
+func (g *testGraph) EdgeList() [][2]int {
+	edges := make([][2]int, 0)
+	for u, vs := range g.succ {
+		for _, v := range vs {
+			edges = append(edges, [2]int{u, v})
+		}
+	}
+	return edges
+}
+
+func (g *testGraph) NodeList() []int {
+	nodes := make([]int, 0, len(g.nodes))
+	for n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+func (g *testGraph) IsDirected() bool { return g.directed }
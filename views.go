@@ -0,0 +1,228 @@
+package discrete
+
+// Reversed wraps a Graph and reverses the direction of every edge, without copying the
+// underlying graph: Successors/Predecessors and IsSuccessor/IsPredecessor are swapped, and
+// EdgeList() reports each edge with its endpoints flipped. NodeList, NodeExists, IsAdjacent and
+// Degree are unaffected by reversal and are simply promoted from the embedded Graph.
+//
+// Reversed deliberately does not implement Coster itself: doing so unconditionally would make
+// Reversed{g} satisfy Coster even when g doesn't, breaking the nil-cost fallback that Prim,
+// Kruskal, Dijkstra and AStar all rely on. Use NewReversed when g might be a Coster and the
+// reversed view should stay one too.
+type Reversed struct {
+	Graph
+}
+
+func (r Reversed) Successors(node int) []int   { return r.Graph.Predecessors(node) }
+func (r Reversed) Predecessors(node int) []int { return r.Graph.Successors(node) }
+
+func (r Reversed) IsSuccessor(node, successor int) bool {
+	return r.Graph.IsPredecessor(node, successor)
+}
+
+func (r Reversed) IsPredecessor(node, predecessor int) bool {
+	return r.Graph.IsSuccessor(node, predecessor)
+}
+
+func (r Reversed) EdgeList() [][2]int {
+	edges := r.Graph.EdgeList()
+	reversed := make([][2]int, len(edges))
+	for i, edge := range edges {
+		reversed[i] = [2]int{edge[1], edge[0]}
+	}
+	return reversed
+}
+
+// reversedCoster is what NewReversed returns when the wrapped graph implements Coster: a
+// Reversed that also answers Cost, by delegating to the wrapped graph's Cost in reverse.
+type reversedCoster struct {
+	Reversed
+	coster Coster
+}
+
+func (r reversedCoster) Cost(node1, node2 int) float64 {
+	return r.coster.Cost(node2, node1)
+}
+
+// NewReversed wraps graph the same way Reversed{graph} does, except that when graph implements
+// Coster, the returned value does too.
+func NewReversed(graph Graph) Graph {
+	r := Reversed{graph}
+	if coster, ok := graph.(Coster); ok {
+		return reversedCoster{r, coster}
+	}
+	return r
+}
+
+// EdgeFiltered wraps a Graph and hides any edge for which Keep returns false, without
+// mutating or copying the underlying graph.
+type EdgeFiltered struct {
+	Graph
+	Keep func(u, v int) bool
+}
+
+func (f EdgeFiltered) Successors(node int) []int {
+	kept := make([]int, 0)
+	for _, succ := range f.Graph.Successors(node) {
+		if f.Keep(node, succ) {
+			kept = append(kept, succ)
+		}
+	}
+	return kept
+}
+
+func (f EdgeFiltered) Predecessors(node int) []int {
+	kept := make([]int, 0)
+	for _, pred := range f.Graph.Predecessors(node) {
+		if f.Keep(pred, node) {
+			kept = append(kept, pred)
+		}
+	}
+	return kept
+}
+
+func (f EdgeFiltered) IsSuccessor(node, successor int) bool {
+	return f.Keep(node, successor) && f.Graph.IsSuccessor(node, successor)
+}
+
+func (f EdgeFiltered) IsPredecessor(node, predecessor int) bool {
+	return f.Keep(predecessor, node) && f.Graph.IsPredecessor(node, predecessor)
+}
+
+func (f EdgeFiltered) IsAdjacent(node, neighbor int) bool {
+	return f.IsSuccessor(node, neighbor) || f.IsPredecessor(node, neighbor)
+}
+
+func (f EdgeFiltered) Degree(node int) int {
+	return len(f.Successors(node)) + len(f.Predecessors(node))
+}
+
+func (f EdgeFiltered) EdgeList() [][2]int {
+	kept := make([][2]int, 0)
+	for _, edge := range f.Graph.EdgeList() {
+		if f.Keep(edge[0], edge[1]) {
+			kept = append(kept, edge)
+		}
+	}
+	return kept
+}
+
+// NodeFiltered wraps a Graph and hides any node for which Keep returns false, along with every
+// edge touching it, without mutating or copying the underlying graph.
+type NodeFiltered struct {
+	Graph
+	Keep func(node int) bool
+}
+
+func (f NodeFiltered) Successors(node int) []int {
+	if !f.Keep(node) {
+		return nil
+	}
+	kept := make([]int, 0)
+	for _, succ := range f.Graph.Successors(node) {
+		if f.Keep(succ) {
+			kept = append(kept, succ)
+		}
+	}
+	return kept
+}
+
+func (f NodeFiltered) Predecessors(node int) []int {
+	if !f.Keep(node) {
+		return nil
+	}
+	kept := make([]int, 0)
+	for _, pred := range f.Graph.Predecessors(node) {
+		if f.Keep(pred) {
+			kept = append(kept, pred)
+		}
+	}
+	return kept
+}
+
+func (f NodeFiltered) IsSuccessor(node, successor int) bool {
+	return f.Keep(node) && f.Keep(successor) && f.Graph.IsSuccessor(node, successor)
+}
+
+func (f NodeFiltered) IsPredecessor(node, predecessor int) bool {
+	return f.Keep(node) && f.Keep(predecessor) && f.Graph.IsPredecessor(node, predecessor)
+}
+
+func (f NodeFiltered) IsAdjacent(node, neighbor int) bool {
+	return f.Keep(node) && f.Keep(neighbor) && f.Graph.IsAdjacent(node, neighbor)
+}
+
+func (f NodeFiltered) NodeExists(node int) bool {
+	return f.Keep(node) && f.Graph.NodeExists(node)
+}
+
+func (f NodeFiltered) Degree(node int) int {
+	if !f.Keep(node) {
+		return 0
+	}
+	return len(f.Successors(node)) + len(f.Predecessors(node))
+}
+
+func (f NodeFiltered) NodeList() []int {
+	kept := make([]int, 0)
+	for _, node := range f.Graph.NodeList() {
+		if f.Keep(node) {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
+
+func (f NodeFiltered) EdgeList() [][2]int {
+	kept := make([][2]int, 0)
+	for _, edge := range f.Graph.EdgeList() {
+		if f.Keep(edge[0]) && f.Keep(edge[1]) {
+			kept = append(kept, edge)
+		}
+	}
+	return kept
+}
+
+// Freeze returns a read-only Graph view over graph. Unlike simply handing out graph as a
+// Graph, the result panics if a caller type-asserts their way back to MutableGraph and calls
+// any of its mutating methods, mirroring the ergonomics of the frozen graph wrappers common in
+// other graph libraries.
+func Freeze(graph MutableGraph) Graph {
+	return frozenGraph{graph}
+}
+
+type frozenGraph struct {
+	MutableGraph
+}
+
+func (frozenGraph) NewNode(successors []int) int {
+	panic("discrete: mutation of a frozen graph")
+}
+
+func (frozenGraph) AddNode(id int, successors []int) {
+	panic("discrete: mutation of a frozen graph")
+}
+
+func (frozenGraph) AddEdge(node1, node2 int) {
+	panic("discrete: mutation of a frozen graph")
+}
+
+func (frozenGraph) SetEdgeCost(node1, node2 int, cost float64) {
+	panic("discrete: mutation of a frozen graph")
+}
+
+func (frozenGraph) RemoveNode(node int) {
+	panic("discrete: mutation of a frozen graph")
+}
+
+func (frozenGraph) RemoveEdge(node1, node2 int) {
+	panic("discrete: mutation of a frozen graph")
+}
+
+func (frozenGraph) EmptyGraph() {
+	panic("discrete: mutation of a frozen graph")
+}
+
+func (frozenGraph) SetDirected(bool) {
+	panic("discrete: mutation of a frozen graph")
+}
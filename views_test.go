@@ -0,0 +1,101 @@
+package discrete
+
+import "testing"
+
+func TestEdgeFiltered(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}, {1, 3}, {2, 3}})
+	f := EdgeFiltered{g, func(u, v int) bool { return v != 3 }}
+
+	if got := f.Successors(1); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Successors(1) = %v, want [2]", got)
+	}
+	if f.IsSuccessor(1, 3) {
+		t.Error("IsSuccessor(1, 3) should be false, that edge is filtered out")
+	}
+	if !f.IsSuccessor(1, 2) {
+		t.Error("IsSuccessor(1, 2) should still be true")
+	}
+	for _, edge := range f.EdgeList() {
+		if edge[1] == 3 {
+			t.Errorf("EdgeList() should not contain an edge into 3, got %v", edge)
+		}
+	}
+}
+
+func TestNodeFiltered(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}, {2, 3}, {1, 3}})
+	f := NodeFiltered{g, func(node int) bool { return node != 3 }}
+
+	if got := f.Successors(1); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Successors(1) = %v, want [2]", got)
+	}
+	if f.NodeExists(3) {
+		t.Error("NodeExists(3) should be false, node 3 is filtered out")
+	}
+	if f.IsAdjacent(1, 3) {
+		t.Error("IsAdjacent(1, 3) should be false, node 3 is filtered out")
+	}
+	for _, node := range f.NodeList() {
+		if node == 3 {
+			t.Error("NodeList() should not contain the filtered-out node 3")
+		}
+	}
+}
+
+func TestFreezePanicsOnMutation(t *testing.T) {
+	g := &mutableTestGraph{testGraph: newTestGraph([][2]int{{1, 2}})}
+	frozen := Freeze(g)
+
+	mg, ok := frozen.(MutableGraph)
+	if !ok {
+		t.Fatal("Freeze should still return something assertable back to MutableGraph")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("AddEdge on a frozen graph should panic")
+		}
+	}()
+	mg.AddEdge(1, 2)
+}
+
+func TestNewReversedCoster(t *testing.T) {
+	g := newWeightedTestGraph([][2]int{{1, 2}}, map[[2]int]float64{{1, 2}: 5})
+
+	r := NewReversed(g)
+	coster, ok := r.(Coster)
+	if !ok {
+		t.Fatal("NewReversed(g) should implement Coster when g does")
+	}
+	if got := coster.Cost(2, 1); got != 5 {
+		t.Errorf("Cost(2, 1) = %v, want 5 (g's Cost(1, 2) reversed)", got)
+	}
+	if !r.(Graph).IsSuccessor(2, 1) {
+		t.Error("NewReversed(g) should still reverse edges like Reversed does")
+	}
+}
+
+func TestNewReversedNonCoster(t *testing.T) {
+	g := newTestGraph([][2]int{{1, 2}})
+
+	r := NewReversed(g)
+	if _, ok := r.(Coster); ok {
+		t.Error("NewReversed(g) should not implement Coster when g doesn't")
+	}
+}
+
+// mutableTestGraph adds the minimal MutableGraph methods on top of testGraph, enough to
+// exercise Freeze's panics; it doesn't need to support real mutation since every method panics
+// before touching the embedded graph.
+type mutableTestGraph struct {
+	*testGraph
+}
+
+func (g *mutableTestGraph) NewNode(successors []int) int               { return 0 }
+func (g *mutableTestGraph) AddNode(id int, successors []int)           {}
+func (g *mutableTestGraph) AddEdge(node1, node2 int)                   {}
+func (g *mutableTestGraph) SetEdgeCost(node1, node2 int, cost float64) {}
+func (g *mutableTestGraph) RemoveNode(node int)                        {}
+func (g *mutableTestGraph) RemoveEdge(node1, node2 int)                {}
+func (g *mutableTestGraph) EmptyGraph()                                {}
+func (g *mutableTestGraph) SetDirected(bool)                           {}